@@ -2,23 +2,38 @@ package LRU_Cache
 
 import (
 	"container/list"
+	"sync"
+	"time"
 )
 
 //核心数据结构
-//实现LRU_Cache，但是并发访问不安全。
+//实现LRU_Cache。支持可选的 TTL 过期，一旦配置了清理周期（cleanupInterval）就会有后台 goroutine 清理过期数据，
+//因此内部用互斥锁保护，可以并发访问。
 type Cache struct {
+	mu       sync.Mutex
 	maxBytes int64                    //允许使用的最大内存
 	nbytes   int64                    //当前已使用的内存
 	ll       *list.List               //内置双向链表
 	cache    map[string]*list.Element //键是字符串，值是双向链表中对应节点的指针
 	//当条目被清除时执行。
 	OnEvicted func(key string, value Value) //某条记录被移除时的回调函数，可以为 nil
+
+	defaultTTL      time.Duration //通过 NewWithTTL 配置的默认过期时间，零值表示不过期
+	cleanupInterval time.Duration //janitor 的清理周期，零值表示不启动 janitor
+	stopCh          chan struct{}
+
+	policy AdmissionPolicy //nil 表示纯 LRU，非 nil 时 Add 会在缓存已满时先做准入判断
 }
 
 //键值对 entry 是双向链表节点的数据类型，在链表中仍保存每个值对应的 key 的好处在于，淘汰队首节点时，需要用 key 从字典中删除对应的映射
 type entry struct {
-	key   string
-	value Value
+	key      string
+	value    Value
+	expireAt time.Time //零值表示永不过期
+}
+
+func (e *entry) expired() bool {
+	return !e.expireAt.IsZero() && time.Now().After(e.expireAt)
 }
 
 //该接口只包含了一个方法 Len() int，用于返回值所占用的内存大小。
@@ -36,14 +51,45 @@ func New(maxBytes int64, onEvicted func(string, Value)) *Cache {
 	}
 }
 
+//NewWithTTL 在 New 的基础上配置默认 TTL 与清理周期。
+//当 cleanupInterval 非零时会启动一个 janitor goroutine，定期清理过期数据，调用方应在用完后调用 Close()。
+func NewWithTTL(maxBytes int64, onEvicted func(string, Value), defaultTTL, cleanupInterval time.Duration) *Cache {
+	c := New(maxBytes, onEvicted)
+	c.defaultTTL = defaultTTL
+	c.cleanupInterval = cleanupInterval
+	if cleanupInterval > 0 {
+		c.stopCh = make(chan struct{})
+		go c.janitor()
+	}
+	return c
+}
+
+//NewTinyLFU 在 New 的基础上装配一个 TinyLFU 准入策略：当缓存已满时，只有频率估计高于当前
+//LRU 淘汰候选的新 key 才会被放入缓存，否则直接丢弃而不发生淘汰。capacity 应约等于预期的条目数量，
+//用于确定 Count-Min Sketch 的宽度。
+func NewTinyLFU(maxBytes int64, onEvicted func(string, Value), capacity int) *Cache {
+	c := New(maxBytes, onEvicted)
+	c.policy = newTinyLFU(capacity)
+	return c
+}
+
 //查找功能
 //第一步是从字典中找到对应的双向链表的节点，第二步，将该节点移动到队尾
 func (c *Cache) Get(key string) (value Value, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.policy != nil {
+		c.policy.Record(key)
+	}
 	//如果键对应的链表节点存在，则将对应节点移动到队尾，并返回查找到的值。
 	//c.ll.MoveToFront(ele)，即将链表中的节点 ele 移动到队尾（双向链表作为队列，队首队尾是相对的，在这里约定 front 为队尾）
 	if ele, ok := c.cache[key]; ok {
-		c.ll.MoveToFront(ele)
 		kv := ele.Value.(*entry)
+		if kv.expired() {
+			c.removeElement(ele)
+			return nil, false
+		}
+		c.ll.MoveToFront(ele)
 		return kv.value, true
 	}
 	return
@@ -52,37 +98,113 @@ func (c *Cache) Get(key string) (value Value, ok bool) {
 //删除
 //实际上是缓存淘汰。即移除最近最少访问的节点（队首）
 func (c *Cache) RemoveOldest() {
-	ele := c.ll.Back()
-	if ele != nil {
-		c.ll.Remove(ele) //c.ll.Back() 取到队首节点，从链表中删除
-		kv := ele.Value.(*entry)
-		delete(c.cache, kv.key)                                //delete(c.cache, kv.key)，从字典中 c.cache 删除该节点的映射关系
-		c.nbytes -= int64(len(kv.key)) + int64(kv.value.Len()) //更新当前所用的内存 c.nbytes
-		if c.OnEvicted != nil {
-			c.OnEvicted(kv.key, kv.value) //如果回调函数 OnEvicted 不为 nil，则调用回调函数
-		}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeElement(c.ll.Back())
+}
+
+//removeElement 从链表和字典中移除 ele，并触发 OnEvicted。调用方必须持有 c.mu。
+func (c *Cache) removeElement(ele *list.Element) {
+	if ele == nil {
+		return
+	}
+	c.ll.Remove(ele) //从链表中删除节点 ele（不一定是队首节点）
+	kv := ele.Value.(*entry)
+	delete(c.cache, kv.key)                                //delete(c.cache, kv.key)，从字典中 c.cache 删除该节点的映射关系
+	c.nbytes -= int64(len(kv.key)) + int64(kv.value.Len()) //更新当前所用的内存 c.nbytes
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value) //如果回调函数 OnEvicted 不为 nil，则调用回调函数
 	}
 }
 
-//新增 or 修改
+//新增 or 修改，沿用 NewWithTTL 时配置的默认 TTL（没有配置则永不过期）
 func (c *Cache) Add(key string, value Value) {
+	c.addWithExpire(key, value, c.expireAt(c.defaultTTL))
+}
+
+//AddWithTTL 新增 or 修改一条记录，并指定这条记录独立于默认 TTL 的过期时间，ttl<=0 表示永不过期。
+func (c *Cache) AddWithTTL(key string, value Value, ttl time.Duration) {
+	c.addWithExpire(key, value, c.expireAt(ttl))
+}
+
+//wouldOverflow 判断插入这个新 key 是否会让缓存超过 maxBytes，即是否需要淘汰才能腾出空间。
+func (c *Cache) wouldOverflow(key string, value Value) bool {
+	return c.maxBytes != 0 && c.nbytes+int64(len(key))+int64(value.Len()) > c.maxBytes
+}
+
+func (c *Cache) expireAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func (c *Cache) addWithExpire(key string, value Value, expireAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if ele, ok := c.cache[key]; ok { //如果键存在，则更新对应节点的值，并将该节点移到队尾
 		c.ll.MoveToFront(ele)
 		kv := ele.Value.(*entry)
 		c.nbytes += int64(value.Len()) - int64(kv.value.Len())
 		kv.value = value
-	} else { //不存在则是新增场景，首先队尾添加新节点 &entry{key, value}, 并字典中添加 key 和节点的映射关系
-		ele := c.ll.PushFront(&entry{key, value})
+		kv.expireAt = expireAt
+	} else { //不存在则是新增场景
+		if c.policy != nil && c.wouldOverflow(key, value) {
+			if victim := c.ll.Back(); victim != nil {
+				if !c.policy.Admit(key, victim.Value.(*entry).key) {
+					return //准入策略拒绝了这个新 key，维持缓存现状，不发生淘汰
+				}
+			}
+		}
+		//首先队尾添加新节点 &entry{key, value}, 并字典中添加 key 和节点的映射关系
+		ele := c.ll.PushFront(&entry{key: key, value: value, expireAt: expireAt})
 		c.cache[key] = ele
 		c.nbytes += int64(len(key)) + int64(value.Len())
 	}
 	//更新 c.nbytes，如果超过了设定的最大值 c.maxBytes，则移除最少访问的节点
 	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
-		c.RemoveOldest()
+		c.removeElement(c.ll.Back())
+	}
+}
+
+//janitor 按 cleanupInterval 定期扫描并清理所有已过期的条目。
+func (c *Cache) janitor() {
+	ticker := time.NewTicker(c.cleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.deleteExpired()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+func (c *Cache) deleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	//不同 key 的 TTL 可能互不相同，过期时间与 LRU 顺序并不一致，所以这里不能在遇到第一个
+	//未过期的条目时就提前退出，需要完整扫描一遍。
+	for ele := c.ll.Back(); ele != nil; {
+		prev := ele.Prev()
+		if ele.Value.(*entry).expired() {
+			c.removeElement(ele)
+		}
+		ele = prev
+	}
+}
+
+//Close 停止 janitor goroutine。对没有配置 cleanupInterval 的 Cache 调用是安全的空操作。
+func (c *Cache) Close() {
+	if c.stopCh != nil {
+		close(c.stopCh)
 	}
 }
 
 //为了方便测试，实现 Len() 用来获取添加了多少条数据。
 func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.ll.Len()
 }
@@ -0,0 +1,157 @@
+package LRU_Cache
+
+import (
+	"hash/fnv"
+)
+
+// AdmissionPolicy 让缓存在已满时可以对新 key 做准入判断，而不是无条件淘汰最久未访问的条目。
+// nil policy（Cache 的默认值）保留原始的纯 LRU 语义。
+type AdmissionPolicy interface {
+	// Record 在每次 Get 时调用，用于更新 key 的访问频率估计。
+	Record(key string)
+	// Admit 在缓存已满、即将插入 candidate 时调用，判断它是否应该顶替当前的淘汰候选 victim。
+	Admit(candidate, victim string) bool
+}
+
+// tinyLFU 用 Count-Min Sketch 近似统计每个 key 的访问频率，并用一个 doorkeeper 布隆过滤器
+// 过滤只出现过一次的 key，避免它们污染 sketch，从而让扫描型负载（一次性访问大量冷 key）
+// 不会把真正的热点从缓存里挤出去。
+type tinyLFU struct {
+	sketch     *countMinSketch
+	doorkeeper *bloomFilter
+
+	sampleCounter int //自上次老化以来记录的次数
+	resetAt       int //W，达到这个次数就把 sketch 里的计数全部减半，让频率估计能跟上负载变化
+}
+
+// newTinyLFU 创建一个准入策略，capacity 通常取该 Cache 预期容纳的条目数量。
+func newTinyLFU(capacity int) *tinyLFU {
+	if capacity <= 0 {
+		capacity = 16
+	}
+	return &tinyLFU{
+		sketch:     newCountMinSketch(capacity),
+		doorkeeper: newBloomFilter(capacity),
+		resetAt:    capacity * 10, // W ≈ 10x capacity
+	}
+}
+
+func (t *tinyLFU) Record(key string) {
+	//doorkeeper 只有在 key 第二次出现时才放行给 sketch 计数，第一次出现的“一次性” key 不会进入 sketch。
+	if t.doorkeeper.testAndAdd(key) {
+		t.sketch.increment(key)
+	}
+	t.sampleCounter++
+	if t.sampleCounter >= t.resetAt {
+		t.sketch.halve()
+		t.doorkeeper.reset()
+		t.sampleCounter = 0
+	}
+}
+
+func (t *tinyLFU) Admit(candidate, victim string) bool {
+	return t.sketch.estimate(candidate) > t.sketch.estimate(victim)
+}
+
+var _ AdmissionPolicy = (*tinyLFU)(nil)
+
+//countMinSketch 是一个 4 行、4-bit 饱和计数器的 Count-Min Sketch，用于近似统计 key 的访问频次。
+type countMinSketch struct {
+	width    int
+	counters [4][]uint8
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	width := capacity
+	if width < 16 {
+		width = 16
+	}
+	s := &countMinSketch{width: width}
+	for i := range s.counters {
+		s.counters[i] = make([]uint8, width)
+	}
+	return s
+}
+
+func (s *countMinSketch) indices(key string) [4]uint32 {
+	var idx [4]uint32
+	for i := range idx {
+		idx[i] = hashWithSeed(key, uint32(i)) % uint32(s.width)
+	}
+	return idx
+}
+
+func (s *countMinSketch) increment(key string) {
+	for row, idx := range s.indices(key) {
+		if s.counters[row][idx] < 15 { //4-bit 饱和计数器，最大值 15
+			s.counters[row][idx]++
+		}
+	}
+}
+
+func (s *countMinSketch) estimate(key string) uint8 {
+	min := uint8(15)
+	for row, idx := range s.indices(key) {
+		if c := s.counters[row][idx]; c < min {
+			min = c
+		}
+	}
+	return min
+}
+
+//halve 把所有计数减半，用于老化频率估计，使 sketch 能跟上访问模式的变化。
+func (s *countMinSketch) halve() {
+	for row := range s.counters {
+		for i, c := range s.counters[row] {
+			s.counters[row][i] = c / 2
+		}
+	}
+}
+
+//bloomFilter 是一个简单的位图布隆过滤器，只用来回答“这个 key 是不是第一次见到”。
+type bloomFilter struct {
+	bits []uint64
+	m    int
+}
+
+func newBloomFilter(capacity int) *bloomFilter {
+	m := capacity * 8 //每个 key 大约 8 bit，足够把假阳性率压到可接受的范围
+	if m < 64 {
+		m = 64
+	}
+	return &bloomFilter{bits: make([]uint64, (m+63)/64), m: m}
+}
+
+func (b *bloomFilter) positions(key string) [4]uint32 {
+	var pos [4]uint32
+	for i := range pos {
+		pos[i] = hashWithSeed(key, uint32(i)+100) % uint32(b.m)
+	}
+	return pos
+}
+
+//testAndAdd 返回 key 是否已经在过滤器中出现过，并无条件把它标记为已出现。
+func (b *bloomFilter) testAndAdd(key string) bool {
+	seen := true
+	for _, pos := range b.positions(key) {
+		word, bit := pos/64, pos%64
+		if b.bits[word]&(1<<bit) == 0 {
+			seen = false
+			b.bits[word] |= 1 << bit
+		}
+	}
+	return seen
+}
+
+func (b *bloomFilter) reset() {
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+}
+
+func hashWithSeed(key string, seed uint32) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte{byte(seed), byte(seed >> 8), byte(seed >> 16), byte(seed >> 24)})
+	h.Write([]byte(key))
+	return h.Sum32()
+}
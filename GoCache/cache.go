@@ -0,0 +1,35 @@
+package GoCache
+
+import (
+	"GoCache/LRU_Cache"
+	"sync"
+)
+
+// cache 是对 LRU_Cache.Cache 的并发安全封装，并记录该分片允许使用的最大内存。
+type cache struct {
+	mu         sync.Mutex
+	lru        *LRU_Cache.Cache
+	cacheBytes int64
+}
+
+func (c *cache) add(key string, value ByteView) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	//延迟初始化(Lazy Initialization)，一个对象，只有在使用时才会创建
+	if c.lru == nil {
+		c.lru = LRU_Cache.New(c.cacheBytes, nil)
+	}
+	c.lru.Add(key, value)
+}
+
+func (c *cache) get(key string) (value ByteView, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		return
+	}
+	if v, ok := c.lru.Get(key); ok {
+		return v.(ByteView), ok
+	}
+	return
+}
@@ -0,0 +1,79 @@
+package consistenthash
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+// Hash 将字节数组映射为 uint32，可替换以便于测试。
+type Hash func(data []byte) uint32
+
+// Map 保存所有哈希后的节点（虚拟节点）。
+type Map struct {
+	hash     Hash
+	replicas int            //虚拟节点倍数
+	keys     []int          //哈希环，排序后的虚拟节点哈希值
+	hashMap  map[int]string //虚拟节点哈希值与真实节点的映射关系
+}
+
+// New 创建一个 Map 实例，允许自定义虚拟节点倍数和 Hash 函数。
+func New(replicas int, fn Hash) *Map {
+	m := &Map{
+		replicas: replicas,
+		hash:     fn,
+		hashMap:  make(map[int]string),
+	}
+	if m.hash == nil {
+		m.hash = crc32.ChecksumIEEE
+	}
+	return m
+}
+
+// Add 添加真实节点/机器，为每一个真实节点 key 创建 m.replicas 个虚拟节点。
+func (m *Map) Add(keys ...string) {
+	for _, key := range keys {
+		for i := 0; i < m.replicas; i++ {
+			hash := int(m.hash([]byte(strconv.Itoa(i) + key)))
+			m.keys = append(m.keys, hash)
+			m.hashMap[hash] = key
+		}
+	}
+	sort.Ints(m.keys)
+}
+
+// Remove 从哈希环上移除一个真实节点，删除它在 keys/hashMap 中留下的所有虚拟节点。
+// 如果两个真实节点的某个虚拟节点恰好发生哈希碰撞，只有当该槽位仍然指向被移除的节点时才会删除，
+// 避免误删了后来者。
+func (m *Map) Remove(peer string) {
+	keys := m.keys[:0]
+	for _, hash := range m.keys {
+		if m.hashMap[hash] == peer {
+			delete(m.hashMap, hash)
+			continue
+		}
+		keys = append(keys, hash)
+	}
+	m.keys = keys
+	sort.Ints(m.keys)
+}
+
+// IsEmpty 判断哈希环上是否还有节点。
+func (m *Map) IsEmpty() bool {
+	return len(m.keys) == 0
+}
+
+// Get 获取哈希环上与 key 最接近的真实节点。
+func (m *Map) Get(key string) string {
+	if m.IsEmpty() {
+		return ""
+	}
+
+	hash := int(m.hash([]byte(key)))
+	//顺时针找到第一个匹配的虚拟节点的下标
+	idx := sort.Search(len(m.keys), func(i int) bool {
+		return m.keys[i] >= hash
+	})
+
+	return m.hashMap[m.keys[idx%len(m.keys)]]
+}
@@ -0,0 +1,206 @@
+package GoCache
+
+import (
+	pb "GoCache/gocachepb"
+	"GoCache/singleflight"
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// Getter 加载键值的回调函数，当缓存未命中时由调用方提供真实数据源（数据库、文件等）。
+type Getter interface {
+	Get(key string) ([]byte, error)
+}
+
+// GetterFunc 是实现了 Getter 接口的函数类型。
+type GetterFunc func(key string) ([]byte, error)
+
+// Get 实现 Getter 接口函数
+func (f GetterFunc) Get(key string) ([]byte, error) {
+	return f(key)
+}
+
+// hotCacheBudgetDivisor 决定 hotCache 相对于 mainCache 的内存配额，
+// hotCache 只是"顺路"缓存，不能反过来挤占本节点真正拥有的数据。
+const hotCacheBudgetDivisor = 8
+
+// hotCacheProbability 表示一次远程命中有 1/hotCacheProbability 的概率被写入 hotCache，
+// 避免所有节点都把全部远程数据缓存一遍，造成数据膨胀。
+const hotCacheProbability = 10
+
+// Group 是一个缓存命名空间，关联着对应的数据加载方式，是 GoCache 最核心的数据结构。
+type Group struct {
+	name   string
+	getter Getter //缓存未命中时获取源数据的回调
+	//mainCache 保存一致性哈希环上分配给本节点的 key，hotCache 保存"借住"一下的远程节点的热点 key。
+	mainCache cache
+	hotCache  cache
+	peers     PeerPicker
+	// loader 确保同一个 key 只会有一次加载动作在进行，不论加载方式是本地 Getter 还是远程 RPC，
+	// 从而在突发流量下避免缓存击穿（大量并发请求打到同一个源头）。
+	loader *singleflight.Group
+
+	stats statsCounters
+}
+
+// statsCounters 用原子计数器记录 Group 的运行数据，字段含义见 Stats。
+type statsCounters struct {
+	gets         int64
+	hits         int64
+	peerLoads    int64
+	localLoads   int64
+	loaderErrors int64
+}
+
+// Stats 是 Group 运行数据的一次快照，命名参考 groupcache 的 Stats 结构。
+type Stats struct {
+	Gets         int64 `json:"gets"`          //Get 被调用的总次数
+	Hits         int64 `json:"hits"`          //命中 mainCache 或 hotCache 的次数
+	PeerLoads    int64 `json:"peer_loads"`    //成功从远程节点取到数据的次数
+	LocalLoads   int64 `json:"local_loads"`   //成功通过本地 Getter 加载到数据的次数
+	LoaderErrors int64 `json:"loader_errors"` //本地 Getter 回调返回错误的次数
+}
+
+// Stats 返回该 Group 运行数据的一份快照，用于诸如 HTTPPool 的 _stats 运维端点。
+func (g *Group) Stats() Stats {
+	return Stats{
+		Gets:         atomic.LoadInt64(&g.stats.gets),
+		Hits:         atomic.LoadInt64(&g.stats.hits),
+		PeerLoads:    atomic.LoadInt64(&g.stats.peerLoads),
+		LocalLoads:   atomic.LoadInt64(&g.stats.localLoads),
+		LoaderErrors: atomic.LoadInt64(&g.stats.loaderErrors),
+	}
+}
+
+// Groups 返回当前所有已注册的 Group 的快照，便于运维端点按名称遍历统计信息。
+func Groups() map[string]*Group {
+	mu.RLock()
+	defer mu.RUnlock()
+	snapshot := make(map[string]*Group, len(groups))
+	for name, g := range groups {
+		snapshot[name] = g
+	}
+	return snapshot
+}
+
+var (
+	mu     sync.RWMutex
+	groups = make(map[string]*Group)
+)
+
+// NewGroup 实例化 Group，并且将 group 存储在全局变量 groups 中。
+func NewGroup(name string, cacheBytes int64, getter Getter) *Group {
+	if getter == nil {
+		panic("nil Getter")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	g := &Group{
+		name:      name,
+		getter:    getter,
+		mainCache: cache{cacheBytes: cacheBytes},
+		hotCache:  cache{cacheBytes: cacheBytes / hotCacheBudgetDivisor},
+		loader:    &singleflight.Group{},
+	}
+	groups[name] = g
+	return g
+}
+
+// GetGroup 用来特定名称的 Group，这里使用了只读锁 RLock()，因为不涉及任何冲突变量的写操作。
+func GetGroup(name string) *Group {
+	mu.RLock()
+	g := groups[name]
+	mu.RUnlock()
+	return g
+}
+
+// RegisterPeers 将实现了 PeerPicker 接口的 HTTPPool 注入到 Group 中。
+func (g *Group) RegisterPeers(peers PeerPicker) {
+	if g.peers != nil {
+		panic("RegisterPeerPicker called more than once")
+	}
+	g.peers = peers
+}
+
+// Get 流程：
+// 1. 从 mainCache 查找，命中则返回；
+// 2. 从 hotCache 查找，命中则返回（远程节点的数据恰好被本节点搭载了一份）；
+// 3. 都未命中则调用 load 方法。
+// ctx 会一路传递到远程节点的 PeerGetter，使调用方设置的超时/取消在跨节点 RPC 上同样生效。
+func (g *Group) Get(ctx context.Context, key string) (ByteView, error) {
+	if key == "" {
+		return ByteView{}, fmt.Errorf("key is required")
+	}
+	atomic.AddInt64(&g.stats.gets, 1)
+
+	if v, ok := g.mainCache.get(key); ok {
+		log.Println("[GoCache] hit (main)")
+		atomic.AddInt64(&g.stats.hits, 1)
+		return v, nil
+	}
+	if v, ok := g.hotCache.get(key); ok {
+		log.Println("[GoCache] hit (hot)")
+		atomic.AddInt64(&g.stats.hits, 1)
+		return v, nil
+	}
+
+	return g.load(ctx, key)
+}
+
+// load 通过 singleflight 包装实际的加载动作，保证并发的多次 Get 对同一个 key
+// 只会触发一次本地加载或一次远程 RPC，所有等待者共享同一份结果。
+func (g *Group) load(ctx context.Context, key string) (value ByteView, err error) {
+	viewi, err := g.loader.Do(key, func() (interface{}, error) {
+		if g.peers != nil {
+			if peer, ok := g.peers.PickPeer(key); ok {
+				if value, err := g.getFromPeer(ctx, peer, key); err == nil {
+					return value, nil
+				} else {
+					log.Println("[GoCache] Failed to get from peer", err)
+				}
+			}
+		}
+		return g.getLocally(key)
+	})
+	if err == nil {
+		return viewi.(ByteView), nil
+	}
+	return
+}
+
+// getLocally 调用用户回调函数 g.getter.Get() 获取源数据，并且将源数据添加到 mainCache 中（本节点拥有这份数据）。
+func (g *Group) getLocally(key string) (ByteView, error) {
+	bytes, err := g.getter.Get(key)
+	if err != nil {
+		atomic.AddInt64(&g.stats.loaderErrors, 1)
+		return ByteView{}, err
+	}
+	atomic.AddInt64(&g.stats.localLoads, 1)
+	value := ByteView{b: cloneBytes(bytes)}
+	g.mainCache.add(key, value)
+	return value, nil
+}
+
+// getFromPeer 通过 PeerGetter 从远程节点获取数据，并以较低的概率把它缓存进 hotCache，
+// 让后续对这个热点 key 的访问可以省掉一次 RPC。
+func (g *Group) getFromPeer(ctx context.Context, peer PeerGetter, key string) (ByteView, error) {
+	req := &pb.Request{
+		Group: g.name,
+		Key:   key,
+	}
+	res := &pb.Response{}
+	err := peer.Get(ctx, req, res)
+	if err != nil {
+		return ByteView{}, err
+	}
+	atomic.AddInt64(&g.stats.peerLoads, 1)
+	value := ByteView{b: res.Value}
+	if rand.Intn(hotCacheProbability) == 0 {
+		g.hotCache.add(key, value)
+	}
+	return value, nil
+}
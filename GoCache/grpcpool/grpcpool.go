@@ -0,0 +1,163 @@
+package grpcpool
+
+import (
+	"GoCache"
+	"GoCache/consistenthash"
+	pb "GoCache/gocachepb"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const defaultReplicas = 50
+
+// GRPCPool 是 HTTPPool 的 gRPC 版本：同样维护一致性哈希环与节点选择，
+// 只是节点间通信换成了 *grpc.ClientConn 上的一元 RPC，而不是 net/http。
+type GRPCPool struct {
+	self string
+
+	mu      sync.Mutex
+	peers   *consistenthash.Map
+	getters map[string]*grpcGetter // 每个远程节点一个 grpcGetter，复用其下的连接
+}
+
+// NewGRPCPool 初始化对等方的 gRPC 池，self 为本节点地址，例如 127.0.0.1:8001。
+func NewGRPCPool(self string) *GRPCPool {
+	return &GRPCPool{self: self}
+}
+
+func (p *GRPCPool) Log(format string, v ...interface{}) {
+	log.Printf("[gRPC Server %s] %s", p.self, fmt.Sprintf(format, v))
+}
+
+// Set 初始装载集群成员列表，重建哈希环上的全部节点。
+func (p *GRPCPool) Set(peers ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.peers = consistenthash.New(defaultReplicas, nil)
+	p.peers.Add(peers...)
+	p.getters = make(map[string]*grpcGetter, len(peers))
+	for _, peer := range peers {
+		p.getters[peer] = &grpcGetter{addr: peer}
+	}
+}
+
+// AddPeer 增量加入一个节点，不影响哈希环上已有节点的归属。
+func (p *GRPCPool) AddPeer(peer string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.peers == nil {
+		p.peers = consistenthash.New(defaultReplicas, nil)
+		p.getters = make(map[string]*grpcGetter)
+	}
+	p.peers.Add(peer)
+	p.getters[peer] = &grpcGetter{addr: peer}
+}
+
+// RemovePeer 将一个节点从哈希环上摘除，并关闭它对应的连接。
+func (p *GRPCPool) RemovePeer(peer string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.peers == nil {
+		return
+	}
+	p.peers.Remove(peer)
+	if g, ok := p.getters[peer]; ok {
+		g.close()
+		delete(p.getters, peer)
+	}
+}
+
+// PickPeer 包装一致性哈希的 Get() 方法，根据 key 选择节点，返回其 gRPC 客户端。
+func (p *GRPCPool) PickPeer(key string) (GoCache.PeerGetter, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.peers == nil || p.peers.IsEmpty() {
+		return nil, false
+	}
+	if peer := p.peers.Get(key); peer != "" && peer != p.self {
+		p.Log("Pick peer %s", peer)
+		return p.getters[peer], true
+	}
+	return nil, false
+}
+
+var _ GoCache.PeerPicker = (*GRPCPool)(nil)
+
+// grpcGetter 实现 GoCache.PeerGetter，每个远程节点复用同一个 *grpc.ClientConn，
+// 避免每次 RPC 都重新建连。
+type grpcGetter struct {
+	addr string
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+func (g *grpcGetter) connection() (*grpc.ClientConn, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.conn != nil {
+		return g.conn, nil
+	}
+	conn, err := grpc.NewClient(g.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial peer %s: %v", g.addr, err)
+	}
+	g.conn = conn
+	return conn, nil
+}
+
+func (g *grpcGetter) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.conn != nil {
+		g.conn.Close()
+		g.conn = nil
+	}
+}
+
+// Get 通过 CacheService.Get RPC 从远程节点取值，ctx 带着 Group.Get 设置的超时/取消一并传入，
+// 使调用方可以控制一次跨节点查找最多等待多久。
+func (g *grpcGetter) Get(ctx context.Context, in *pb.Request, out *pb.Response) error {
+	conn, err := g.connection()
+	if err != nil {
+		return err
+	}
+	resp, err := pb.NewCacheServiceClient(conn).Get(ctx, in)
+	if err != nil {
+		return err
+	}
+	out.Value = resp.Value
+	return nil
+}
+
+var _ GoCache.PeerGetter = (*grpcGetter)(nil)
+
+// Server 实现 pb.CacheServiceServer，把收到的 RPC 转发给对应 Group，是 HTTPPool.ServeHTTP 的 gRPC 对应物。
+type Server struct {
+	pb.UnimplementedCacheServiceServer
+	self string
+}
+
+// NewServer 创建一个 gRPC 服务端，self 记录本节点地址；Server 的方法不读取它。
+func NewServer(self string) *Server {
+	return &Server{self: self}
+}
+
+func (s *Server) Get(ctx context.Context, in *pb.Request) (*pb.Response, error) {
+	group := GoCache.GetGroup(in.GetGroup())
+	if group == nil {
+		return nil, fmt.Errorf("no such group: %s", in.GetGroup())
+	}
+	view, err := group.Get(ctx, in.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Response{Value: view.ByteSlice()}, nil
+}
+
+var _ pb.CacheServiceServer = (*Server)(nil)
@@ -3,12 +3,16 @@ package GoCache
 import (
 	"GoCache/consistenthash"
 	pb "GoCache/gocachepb"
+	"context"
+	"encoding/json"
 	"fmt"
 	"google.golang.org/protobuf/proto"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -17,6 +21,9 @@ import (
 const (
 	defultBasePath  = "/_gocache/"
 	defaultReplicas = 50
+
+	statsSuffix = "_stats" //GET {basePath}_stats，返回各 Group 的统计数据
+	peersSuffix = "_peers" //GET {basePath}_peers，返回当前哈希环上的节点列表
 )
 
 //HTTPPool 只有 2 个参数，
@@ -65,12 +72,27 @@ func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if !strings.HasPrefix(r.URL.Path, p.basePath) {
 		str := "HTTPPool serving unexpected path: " + r.URL.Path
 		fmt.Println("error:" + str)
+		http.Error(w, str, http.StatusBadRequest)
 		return
 	}
 	p.Log("%s %s", r.Method, r.URL.Path)
 
+	switch r.URL.Path[len(p.basePath):] {
+	case statsSuffix:
+		p.serveStats(w, r)
+		return
+	case peersSuffix:
+		p.servePeers(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
 	// /<basepath>/<groupname>/<key> 必填
-	parts := strings.SplitN(string(r.URL.Path[len(p.basePath)]), "/", 2)
+	parts := strings.SplitN(r.URL.Path[len(p.basePath):], "/", 2)
 	if len(parts) != 2 {
 		http.Error(w, "bad request", http.StatusBadRequest)
 		return
@@ -82,18 +104,71 @@ func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "no such group:"+groupName, http.StatusNotFound)
 		return
 	}
-	view, err := group.Get(key)
+	view, err := group.Get(r.Context(), key)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	//w.Header().Set("Content-Type", "application/octet-stream")
-	//w.Write(view.ByteSlice())
+
+	//HEAD 只需要确认 key 是否存在及其大小，不必把负载传输一遍。
+	if r.Method == http.MethodHead {
+		w.Header().Set("Content-Length", strconv.Itoa(view.Len()))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	p.writeValue(w, r, view)
+}
+
+//writeValue 根据 Accept 头在 protobuf 和 JSON 之间做内容协商，默认仍是 protobuf，
+//但带上 Accept: application/json 的请求（例如直接用 curl 调试）可以拿到可读的 JSON。
+func (p *HTTPPool) writeValue(w http.ResponseWriter, r *http.Request, view ByteView) {
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		body, err := json.Marshal(struct {
+			Value string `json:"value"`
+		}{Value: view.String()})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+		return
+	}
+
 	body, err := proto.Marshal(&pb.Response{Value: view.ByteSlice()})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(body)
+}
+
+//serveStats 返回每个 Group 的统计数据（gets/hits/peer-loads/local-loads/loader-errors），
+//对应 groupcache 的 Stats，方便运维排查缓存命中率。
+func (p *HTTPPool) serveStats(w http.ResponseWriter, r *http.Request) {
+	stats := make(map[string]Stats)
+	for name, g := range Groups() {
+		stats[name] = g.Stats()
+	}
+	body, err := json.Marshal(stats)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+//servePeers 返回当前哈希环上的节点列表，方便运维确认集群成员是否符合预期。
+func (p *HTTPPool) servePeers(w http.ResponseWriter, r *http.Request) {
+	body, err := json.Marshal(p.Peers())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
 	w.Write(body)
 }
 
@@ -101,7 +176,7 @@ func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 //使用 http.Get() 方式获取返回值，并转换为 []bytes 类型。
 //func (h *httpGetter) Get(group string, key string) ([]byte, error)
-func (h *httpGetter) Get(in *pb.Request, out *pb.Response) error {
+func (h *httpGetter) Get(ctx context.Context, in *pb.Request, out *pb.Response) error {
 	//u := fmt.Sprintf("%v%v/%v", h.baseURL, url.QueryEscape(group), url.QueryEscape(key))
 	//res, err := http.Get(u)
 	u := fmt.Sprintf(
@@ -110,7 +185,11 @@ func (h *httpGetter) Get(in *pb.Request, out *pb.Response) error {
 		url.QueryEscape(in.GetGroup()),
 		url.QueryEscape(in.GetKey()),
 	)
-	res, err := http.Get(u)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -135,6 +214,8 @@ var _ PeerGetter = (*httpGetter)(nil)
 //实现 PeerPicker 接口
 
 //Set() 方法实例化了一致性哈希算法，并且添加了传入的节点
+//用于初始装载成员列表；运行期的增删节点请使用 AddPeer/RemovePeer，
+//避免每次变更都重建整个哈希环、打乱所有已分配的 key。
 func (p *HTTPPool) Set(peers ...string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -148,11 +229,37 @@ func (p *HTTPPool) Set(peers ...string) {
 	}
 }
 
+// AddPeer 在不影响其余节点的前提下把一个新节点增量加入哈希环，用于集群的弹性扩容。
+func (p *HTTPPool) AddPeer(peer string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.peers == nil {
+		p.peers = consistenthash.New(defaultReplicas, nil)
+		p.httpGetters = make(map[string]*httpGetter)
+	}
+	p.peers.Add(peer)
+	p.httpGetters[peer] = &httpGetter{baseURL: peer + p.basePath}
+}
+
+// RemovePeer 把一个节点从哈希环上摘除，并丢弃它对应的 httpGetter，用于节点下线/缩容。
+func (p *HTTPPool) RemovePeer(peer string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.peers == nil {
+		return
+	}
+	p.peers.Remove(peer)
+	delete(p.httpGetters, peer)
+}
+
 //PickerPeer() 包装了一致性哈希算法的 Get() 方法，根据具体的 key，选择节点，返回节点对应的 HTTP 客户端。
 //HTTPPool 既具备了提供 HTTP 服务的能力，也具备了根据具体的 key，创建 HTTP 客户端从远程节点获取缓存值的能力。
 func (p *HTTPPool) PickPeer(key string) (PeerGetter, bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if p.peers == nil {
+		return nil, false
+	}
 	if peer := p.peers.Get(key); peer != "" && peer != p.self {
 		p.Log("Pick peer %s", peer)
 		return p.httpGetters[peer], true
@@ -161,3 +268,15 @@ func (p *HTTPPool) PickPeer(key string) (PeerGetter, bool) {
 }
 
 var _ PeerPicker = (*HTTPPool)(nil)
+
+//Peers 返回当前哈希环上登记的节点地址列表（按字典序排序，便于比较）。
+func (p *HTTPPool) Peers() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	peers := make([]string, 0, len(p.httpGetters))
+	for peer := range p.httpGetters {
+		peers = append(peers, peer)
+	}
+	sort.Strings(peers)
+	return peers
+}
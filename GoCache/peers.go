@@ -1,10 +1,14 @@
 package GoCache
 
-import pb "GoCache/gocachepb"
+import (
+	"context"
+
+	pb "GoCache/gocachepb"
+)
 
 /*
 使用一致性哈希选择节点        是                                    是
-|-----> 是否是远程节点 -----> HTTP 客户端访问远程节点 --> 成功？-----> 服务端返回返回值
+|-----> 是否是远程节点 -----> 客户端访问远程节点(HTTP/gRPC) --> 成功？-----> 服务端返回返回值
 |  否                                    ↓  否
 |----------------------------> 回退到本地节点处理。
 */
@@ -14,9 +18,9 @@ type PeerPicker interface {
 	PickPeer(key string) (peer PeerGetter, ok bool)
 }
 
-//PeerGetter 就对应于上述流程中的 HTTP 客户端。
+//PeerGetter 就对应于上述流程中访问远程节点的客户端，既可以由 HTTPPool 的 httpGetter 实现，
+//也可以由 grpcpool 的 grpcGetter 实现。ctx 用于让调用方（Group.Get）把取消/超时传递下去。
 type PeerGetter interface {
 	//用于从对应 group 查找缓存值
-	//Get(group string, key string) ([]byte, error)
-	Get(in *pb.Request, out *pb.Response) error
+	Get(ctx context.Context, in *pb.Request, out *pb.Response) error
 }